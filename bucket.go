@@ -0,0 +1,145 @@
+package kodo
+
+import (
+	"fmt"
+
+	"github.com/qiniu/api.v7/storage"
+)
+
+// FileInfo 描述七牛对象存储上一个文件的元信息
+type FileInfo struct {
+	Hash     string
+	Fsize    int64
+	PutTime  int64
+	MimeType string
+	Type     int
+}
+
+// FileType 文件的存储类型
+type FileType int
+
+const (
+	FileTypeStandard FileType = iota
+	FileTypeLowFreq
+	FileTypeArchive
+	FileTypeDeepArchive
+)
+
+// Stat 获取 key 对应文件的元信息
+func (client *Client) Stat(key string) (*FileInfo, error) {
+	info, err := client.res().bktManager.Stat(client.bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{
+		Hash:     info.Hash,
+		Fsize:    info.Fsize,
+		PutTime:  info.PutTime,
+		MimeType: info.MimeType,
+		Type:     info.Type,
+	}, nil
+}
+
+// Delete 删除 key 对应的文件
+func (client *Client) Delete(key string) error {
+	return client.res().bktManager.Delete(client.bucket, key)
+}
+
+// Copy 把当前 bucket 下的 srcKey 复制为 dstKey，force 为 true 时覆盖已存在的 dstKey
+func (client *Client) Copy(srcKey, dstKey string, force bool) error {
+	return client.res().bktManager.Copy(client.bucket, srcKey, client.bucket, dstKey, force)
+}
+
+// Move 把当前 bucket 下的 srcKey 移动为 dstKey，force 为 true 时覆盖已存在的 dstKey
+func (client *Client) Move(srcKey, dstKey string, force bool) error {
+	return client.res().bktManager.Move(client.bucket, srcKey, client.bucket, dstKey, force)
+}
+
+// Fetch 让七牛从 resURL 抓取资源并以 key 存储到当前 bucket
+func (client *Client) Fetch(resURL, key string) (storage.FetchRet, error) {
+	return client.res().bktManager.Fetch(resURL, client.bucket, key)
+}
+
+// AsyncFetch 异步抓取，返回抓取任务的 id，可配合七牛控制台或 API 查询任务状态
+func (client *Client) AsyncFetch(resURL, key string) (storage.AsyncFetchRet, error) {
+	param := storage.AsyncFetchParam{
+		Url:    resURL,
+		Bucket: client.bucket,
+		Key:    key,
+	}
+	return client.res().bktManager.AsyncFetch(param)
+}
+
+// ChangeMime 修改 key 对应文件的 MimeType
+func (client *Client) ChangeMime(key, newMimeType string) error {
+	return client.res().bktManager.ChangeMime(client.bucket, key, newMimeType)
+}
+
+// ChangeType 修改 key 对应文件的存储类型
+func (client *Client) ChangeType(key string, fileType FileType) error {
+	return client.res().bktManager.ChangeType(client.bucket, key, int(fileType))
+}
+
+// DeleteAfterDays 设置 key 对应文件在 days 天后自动删除，days 为 0 表示取消自动删除
+func (client *Client) DeleteAfterDays(key string, days int) error {
+	return client.res().bktManager.DeleteAfterDays(client.bucket, key, days)
+}
+
+// BatchOp 描述一次批量操作中的单个操作
+type BatchOp struct {
+	Op       string // stat/delete/copy/move/chgm/chgtype
+	Key      string
+	DestKey  string   // copy/move 时的目标 key
+	Force    bool     // copy/move 时是否覆盖已存在的 DestKey
+	MimeType string   // chgm 时的新 MimeType
+	FileType FileType // chgtype 时的新存储类型
+}
+
+// batchLimit 七牛批量操作接口单次最多支持的操作数
+const batchLimit = 1000
+
+// Batch 批量执行 stat/delete/copy/move/chgm/chgtype 操作，超过 1000 个操作时自动分批请求，
+// 返回的结果和传入的 ops 一一对应
+// 分批请求中只要有一批返回错误，Batch 就会把遇到的第一个错误一并返回，但仍然会把
+// 已经拿到的结果（包括出错那一批里成功的部分）都放进返回值里，调用方可以按需结合
+// 每个 BatchOpRet.Code 判断具体哪个操作失败了
+// 遇到不认识的 Op 时返回错误，结果里只包含该 Op 所在批次之前各批的结果
+func (client *Client) Batch(ops ...BatchOp) ([]storage.BatchOpRet, error) {
+	results := make([]storage.BatchOpRet, 0, len(ops))
+	var firstErr error
+
+	for start := 0; start < len(ops); start += batchLimit {
+		end := start + batchLimit
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		batchOps := make([]string, 0, end-start)
+		for _, op := range ops[start:end] {
+			switch op.Op {
+			case "stat":
+				batchOps = append(batchOps, storage.URIStat(client.bucket, op.Key))
+			case "delete":
+				batchOps = append(batchOps, storage.URIDelete(client.bucket, op.Key))
+			case "copy":
+				batchOps = append(batchOps, storage.URICopy(client.bucket, op.Key, client.bucket, op.DestKey, op.Force))
+			case "move":
+				batchOps = append(batchOps, storage.URIMove(client.bucket, op.Key, client.bucket, op.DestKey, op.Force))
+			case "chgm":
+				batchOps = append(batchOps, storage.URIChangeMime(client.bucket, op.Key, op.MimeType))
+			case "chgtype":
+				batchOps = append(batchOps, storage.URIChangeType(client.bucket, op.Key, int(op.FileType)))
+			default:
+				return results, fmt.Errorf("kodo: unknown batch op %q", op.Op)
+			}
+		}
+
+		ret, err := client.res().bktManager.Batch(batchOps)
+		results = append(results, ret...)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return results, firstErr
+}
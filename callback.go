@@ -0,0 +1,55 @@
+package kodo
+
+import (
+	"net/http"
+
+	"github.com/qiniu/api.v7/storage"
+)
+
+const defaultCallbackBodyType = "application/json"
+
+// WithCallbackHost 给 Client 设置 CallbackUploadToken 生成的上传凭证里携带的
+// 回调 Host，七牛服务端回调 callbackURL 时会带上这个 Host 头，不设置时使用
+// callbackURL 自带的 host
+func (client *Client) WithCallbackHost(host string) *Client {
+	client.callbackHost = host
+	return client
+}
+
+// VerifyCallback 校验七牛回调请求 req 上的 Authorization: QBox ... 头是否和
+// 客户端的密钥匹配，用于上传/持久化处理的回调接口防止被伪造请求
+func (client *Client) VerifyCallback(req *http.Request) (bool, error) {
+	return client.mac.VerifyCallback(req)
+}
+
+// CallbackUploadToken 生成一个预先配置好服务端回调的上传凭证，上传完成后七牛会
+// 回调 callbackURL，callbackBody 为回调请求体模板，callbackBodyType 为空时默认
+// 使用 application/json，回调 Host 由 WithCallbackHost 配置
+func (client *Client) CallbackUploadToken(ttl uint32, callbackURL, callbackBody, callbackBodyType string) string {
+	if callbackBodyType == "" {
+		callbackBodyType = defaultCallbackBodyType
+	}
+
+	policy := &storage.PutPolicy{
+		Scope:            client.bucket,
+		CallbackURL:      callbackURL,
+		CallbackHost:     client.callbackHost,
+		CallbackBody:     callbackBody,
+		CallbackBodyType: callbackBodyType,
+		Expires:          ttl,
+	}
+	return policy.UploadToken(client.mac)
+}
+
+// VerifyCallbackMiddleware 是一个 net/http 中间件，拒绝未通过 VerifyCallback
+// 校验的回调请求，用于保护接收七牛上传/持久化处理回调的接口
+func (client *Client) VerifyCallbackMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ok, err := client.VerifyCallback(req)
+		if err != nil || !ok {
+			http.Error(w, "invalid callback signature", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
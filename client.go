@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/qiniu/api.v7/auth/qbox"
@@ -47,19 +48,39 @@ type FopResult struct {
 	Err    string
 }
 
-// Client 七牛对象存储客户端
-type Client struct {
-	accessKey  string
-	secretKey  string
-	bucket     string
-	domain     string
+// clientResources 是一组相互绑定的 *storage.Config 和依赖它的 manager/uploader，
+// AutoZone 模式下刷新 zone 需要整体替换它们，而不是就地修改共享的 Config.Zone 字段，
+// 否则会和正在进行中的上传/bucket 操作对同一个 *storage.Config 产生数据竞争
+type clientResources struct {
 	config     *storage.Config
-	mac        *qbox.Mac
 	bktManager *storage.BucketManager
 	fopManager *storage.OperationManager
 	uploader   *storage.FormUploader
 }
 
+// Client 七牛对象存储客户端
+type Client struct {
+	accessKey string
+	secretKey string
+	bucket    string
+	domain    string
+	mac       *qbox.Mac
+
+	resources atomic.Value // *clientResources
+
+	antiLeechKey string
+	callbackHost string
+
+	autoZone    bool
+	regionCache regionCache
+}
+
+// res 返回当前生效的 clientResources，AutoZone 模式下 RefreshRegion 可能会并发
+// 替换它，所以必须通过这个方法读取，而不是缓存调用方自己持有的引用
+func (client *Client) res() *clientResources {
+	return client.resources.Load().(*clientResources)
+}
+
 func InitDefaultClient(ak, sk, bucket, domain string, args ...interface{}) {
 	DefaultClient = NewClient(ak, sk, bucket, domain, args...)
 }
@@ -67,7 +88,9 @@ func InitDefaultClient(ak, sk, bucket, domain string, args ...interface{}) {
 // NewClient args 是可选参数
 // args[0] = useHTTPS bool
 // args[1] = useCDN   bool
-// args[2] = zoneConf     *conf.ZoneConf 在私有环境部署中需要指定 zone
+// args[2] = zoneConf     *conf.ZoneConf 在私有环境部署中需要指定 zone，不传或传 nil
+//
+//	时进入 AutoZone 模式，首次上传前会调用 Region 自动查询并缓存地域信息
 func NewClient(ak, sk, bucket, domain string, args ...interface{}) *Client {
 
 	var (
@@ -105,22 +128,26 @@ func NewClient(ak, sk, bucket, domain string, args ...interface{}) *Client {
 		Zone:          zone,
 	}
 
-	bktManager := storage.NewBucketManager(mac, &cfg)
-	fopManager := storage.NewOperationManager(mac, &cfg)
-	uploader := storage.NewFormUploader(&cfg)
-
-	return &Client{
-		accessKey:  ak,
-		secretKey:  sk,
-		bucket:     bucket,
-		domain:     domain,
-		config:     &cfg,
-		mac:        mac,
-		bktManager: bktManager,
-		uploader:   uploader,
-		fopManager: fopManager,
+	client := &Client{
+		accessKey: ak,
+		secretKey: sk,
+		bucket:    bucket,
+		domain:    domain,
+		mac:       mac,
+		autoZone:  zone == nil,
 	}
+	client.resources.Store(newClientResources(mac, &cfg))
 
+	return client
+}
+
+func newClientResources(mac *qbox.Mac, cfg *storage.Config) *clientResources {
+	return &clientResources{
+		config:     cfg,
+		bktManager: storage.NewBucketManager(mac, cfg),
+		fopManager: storage.NewOperationManager(mac, cfg),
+		uploader:   storage.NewFormUploader(cfg),
+	}
 }
 
 // extra 用于构造上传到七牛云存储上的扩展名
@@ -181,9 +208,13 @@ func (client *Client) Writer(path string, reader io.Reader, fSize int64, overWri
 		CallbackBody:       cbBody,
 		PersistentPipeline: pipName,
 	}
+	if client.autoZone {
+		client.Region(context.Background())
+	}
+
 	ret := State{}
 	extra := client.extra(extraParams)
-	err := client.uploader.Put(
+	err := client.res().uploader.Put(
 		context.Background(),
 		&ret,
 		policy.UploadToken(client.mac),
@@ -205,7 +236,12 @@ func (client *Client) Bucket() string {
 }
 
 func (client *Client) URLFor(key string) string {
-	deadline := time.Now().Add(time.Minute * 60).Unix()
+	return client.TimedURLFor(key, time.Minute*60)
+}
+
+// TimedURLFor 和 URLFor 一样生成私有下载 url，但有效期由 ttl 指定，而不是固定的 60 分钟
+func (client *Client) TimedURLFor(key string, ttl time.Duration) string {
+	deadline := time.Now().Add(ttl).Unix()
 	return storage.MakePrivateURL(client.mac, client.domain, key, deadline)
 }
 
@@ -244,7 +280,7 @@ func (client *Client) KeyList(prefix string, args ...interface{}) ([]string, str
 	}
 
 	for {
-		items, _, nextMarker, hashNext, err := client.bktManager.ListFiles(client.bucket, prefix, delimiter, marker, limit)
+		items, _, nextMarker, hashNext, err := client.res().bktManager.ListFiles(client.bucket, prefix, delimiter, marker, limit)
 		if err != nil {
 			break
 		}
@@ -267,7 +303,7 @@ func (client *Client) KeyList(prefix string, args ...interface{}) ([]string, str
 }
 
 func (client *Client) KeyExist(key string) bool {
-	_, err := client.bktManager.Stat(client.bucket, key)
+	_, err := client.res().bktManager.Stat(client.bucket, key)
 	if err != nil {
 		return false
 	}
@@ -294,7 +330,7 @@ func (client *Client) Fop(key, fops, pipName string, args ...interface{}) (strin
 		notifyURL = args[0].(string)
 	}
 
-	return client.fopManager.Pfop(
+	return client.res().fopManager.Pfop(
 		client.bucket,
 		key,
 		fops,
@@ -305,7 +341,7 @@ func (client *Client) Fop(key, fops, pipName string, args ...interface{}) (strin
 }
 
 func (client *Client) PreFop(pid string) (*FopState, error) {
-	ret, err := client.fopManager.Prefop(pid)
+	ret, err := client.res().fopManager.Prefop(pid)
 	if err != nil {
 		return nil, err
 	}
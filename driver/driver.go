@@ -0,0 +1,44 @@
+// Package driver 把 kodo.Client 封装成一个通用的存储后端接口，使调用方可以
+// 针对 Driver 编写代码，并在七牛云存储、本地文件系统、S3 兼容存储之间自由切换
+// 而不用改动调用方的代码，方便测试和私有化部署
+package driver
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ObjectInfo 描述一个存储对象的元信息
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	MimeType     string
+	LastModified time.Time
+}
+
+// Driver 是存储后端需要实现的最小能力集合，kodo、本地文件系统、S3 等后端都以此
+// 接口对外提供服务
+type Driver interface {
+	// Put 把 reader 中的数据写入 key 对应的位置
+	Put(ctx context.Context, key string, reader io.Reader, size int64) error
+
+	// Get 读取 key 对应的数据
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Stat 获取 key 对应对象的元信息
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+
+	// Delete 删除 key 对应的对象
+	Delete(ctx context.Context, key string) error
+
+	// List 按前缀列举对象，marker 用于翻页，返回下一页的 marker，marker 为空表示没有更多数据
+	List(ctx context.Context, prefix, marker string, limit int) ([]ObjectInfo, string, error)
+
+	// Sign 为 key 生成一个有效期为 ttl 的可访问下载地址
+	Sign(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// Fop 对 key 执行数据处理指令，返回处理任务 id，不是所有后端都支持，
+	// 不支持时返回 ErrUnsupported
+	Fop(ctx context.Context, key, fops string) (string, error)
+}
@@ -0,0 +1,17 @@
+package driver
+
+import "fmt"
+
+// New 根据 policy.Type 创建对应的 Driver，取值 qiniu/local/s3
+func New(policy *Policy) (Driver, error) {
+	switch policy.Type {
+	case "qiniu":
+		return NewQiniuDriver(policy), nil
+	case "local":
+		return NewLocalDriver(policy)
+	case "s3":
+		return NewS3Driver(policy)
+	default:
+		return nil, fmt.Errorf("driver: unknown policy type %q", policy.Type)
+	}
+}
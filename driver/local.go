@@ -0,0 +1,141 @@
+package driver
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LocalDriver 把本地文件系统的一个目录适配成 Driver 接口，主要用于测试和
+// 不依赖对象存储的私有化部署场景
+type LocalDriver struct {
+	baseDir string
+}
+
+// NewLocalDriver 根据 policy.BaseDir 创建一个基于本地文件系统的 Driver
+func NewLocalDriver(policy *Policy) (*LocalDriver, error) {
+	if err := os.MkdirAll(policy.BaseDir, 0755); err != nil {
+		return nil, err
+	}
+	return &LocalDriver{baseDir: policy.BaseDir}, nil
+}
+
+func (d *LocalDriver) path(key string) string {
+	return filepath.Join(d.baseDir, filepath.FromSlash(key))
+}
+
+func (d *LocalDriver) Put(ctx context.Context, key string, reader io.Reader, size int64) error {
+	fullPath := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(fullPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, reader)
+	return err
+}
+
+func (d *LocalDriver) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(d.path(key))
+}
+
+func (d *LocalDriver) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	fi, err := os.Stat(d.path(key))
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{
+		Key:          key,
+		Size:         fi.Size(),
+		LastModified: fi.ModTime(),
+	}, nil
+}
+
+func (d *LocalDriver) Delete(ctx context.Context, key string) error {
+	return os.Remove(d.path(key))
+}
+
+func (d *LocalDriver) List(ctx context.Context, prefix, marker string, limit int) ([]ObjectInfo, string, error) {
+	keys := make([]string, 0)
+	err := filepath.Walk(d.baseDir, func(fullPath string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(d.baseDir, fullPath)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	sort.Strings(keys)
+
+	start := 0
+	if marker != "" {
+		// marker 之后应该从第一个大于它的 key 开始；如果没有任何 key 比 marker 大
+		// （marker 是陈旧的、或者已经翻到最后一页），说明分页已经结束，直接返回空
+		// 结果而不是退回第一页
+		start = len(keys)
+		for i, k := range keys {
+			if k > marker {
+				start = i
+				break
+			}
+		}
+	}
+
+	if limit <= 0 {
+		limit = len(keys)
+	}
+
+	end := start + limit
+	if end > len(keys) {
+		end = len(keys)
+	}
+
+	infos := make([]ObjectInfo, 0, end-start)
+	for _, key := range keys[start:end] {
+		info, err := d.Stat(ctx, key)
+		if err != nil {
+			return nil, "", err
+		}
+		infos = append(infos, *info)
+	}
+
+	nextMarker := ""
+	if end < len(keys) {
+		nextMarker = keys[end-1]
+	}
+
+	return infos, nextMarker, nil
+}
+
+// Sign 返回 key 在本地文件系统上的路径，ttl 不起作用，返回的路径不会过期
+func (d *LocalDriver) Sign(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return d.path(key), nil
+}
+
+func (d *LocalDriver) Fop(ctx context.Context, key, fops string) (string, error) {
+	return "", ErrUnsupported
+}
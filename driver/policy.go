@@ -0,0 +1,52 @@
+package driver
+
+import "errors"
+
+// ErrUnsupported 表示当前后端不支持该操作
+var ErrUnsupported = errors.New("driver: operation not supported by this backend")
+
+// Policy 描述一个 bucket 对应的后端凭证、地域和回调配置，不同类型的后端只使用
+// 其中与自己相关的字段，例如本地文件系统只关心 BaseDir
+type Policy struct {
+	// Type 后端类型，取值 qiniu/local/s3
+	Type string
+
+	// AccessKey/SecretKey 对象存储的访问凭证，qiniu/s3 使用
+	AccessKey string
+	SecretKey string
+
+	// Bucket 存储空间名字，qiniu/s3 使用
+	Bucket string
+
+	// Domain 用于生成下载地址的自定义域名，qiniu 使用
+	Domain string
+
+	// Zone 私有化部署需要指定的地域配置，qiniu 使用
+	Zone *ZoneConf
+
+	// Endpoint/Region S3 兼容存储的服务地址和地域，s3 使用
+	Endpoint string
+	Region   string
+
+	// UseHTTPS 是否使用 https 访问后端服务
+	UseHTTPS bool
+
+	// BaseDir 本地文件系统后端的根目录
+	BaseDir string
+
+	// CallbackURL/CallbackBody/CallbackBodyType 上传完成后的回调配置，
+	// 内置的 qiniu/local/s3 后端都不读取这三个字段，上传时不会发起回调
+	CallbackURL      string
+	CallbackBody     string
+	CallbackBodyType string
+}
+
+// ZoneConf 私有化部署中七牛 zone 的配置，字段含义和 kodo.ZoneConf 一致
+type ZoneConf struct {
+	SrcUpHosts []string
+	CdnUpHosts []string
+	RsHost     string
+	RsfHost    string
+	ApiHost    string
+	IoVipHost  string
+}
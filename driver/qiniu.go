@@ -0,0 +1,102 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/yahaa/kodo"
+)
+
+// QiniuDriver 把 kodo.Client 适配成 Driver 接口
+type QiniuDriver struct {
+	client *kodo.Client
+}
+
+// NewQiniuDriver 根据 policy 创建一个基于七牛云存储的 Driver
+func NewQiniuDriver(policy *Policy) *QiniuDriver {
+	var args []interface{}
+	args = append(args, policy.UseHTTPS)
+	if policy.Zone != nil {
+		args = append(args, false, &kodo.ZoneConf{
+			SrcUpHosts: policy.Zone.SrcUpHosts,
+			CdnUpHosts: policy.Zone.CdnUpHosts,
+			RsHost:     policy.Zone.RsHost,
+			RsfHost:    policy.Zone.RsfHost,
+			ApiHost:    policy.Zone.ApiHost,
+			IoVipHost:  policy.Zone.IoVipHost,
+		})
+	}
+
+	client := kodo.NewClient(policy.AccessKey, policy.SecretKey, policy.Bucket, policy.Domain, args...)
+	return &QiniuDriver{client: client}
+}
+
+func (d *QiniuDriver) Put(ctx context.Context, key string, reader io.Reader, size int64) error {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	_, err = d.client.Push(key, data, true)
+	return err
+}
+
+func (d *QiniuDriver) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.client.URLFor(key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("driver: get %q failed with status %d", key, resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+func (d *QiniuDriver) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, err := d.client.Stat(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Fsize,
+		MimeType:     info.MimeType,
+		LastModified: time.Unix(0, info.PutTime*100),
+	}, nil
+}
+
+func (d *QiniuDriver) Delete(ctx context.Context, key string) error {
+	return d.client.Delete(key)
+}
+
+func (d *QiniuDriver) List(ctx context.Context, prefix, marker string, limit int) ([]ObjectInfo, string, error) {
+	keys, nextMarker := d.client.KeyList(prefix, limit, limit, marker)
+	// KeyList 列举到最后一页时返回的是请求用的 marker，不足 limit 个说明已经没有更多数据
+	if len(keys) < limit {
+		nextMarker = ""
+	}
+	infos := make([]ObjectInfo, 0, len(keys))
+	for _, key := range keys {
+		infos = append(infos, ObjectInfo{Key: key})
+	}
+	return infos, nextMarker, nil
+}
+
+func (d *QiniuDriver) Sign(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return d.client.TimedURLFor(key, ttl), nil
+}
+
+func (d *QiniuDriver) Fop(ctx context.Context, key, fops string) (string, error) {
+	return d.client.Fop(key, fops, fmt.Sprintf("%s-pipeline", d.client.Bucket()))
+}
@@ -0,0 +1,141 @@
+package driver
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3Driver 把一个 S3 兼容的存储服务适配成 Driver 接口，Endpoint 留空时
+// 默认访问 AWS S3，填写后可以对接 MinIO 等私有化部署的 S3 兼容服务
+type S3Driver struct {
+	bucket string
+	api    *s3.S3
+}
+
+// NewS3Driver 根据 policy 创建一个基于 S3 协议的 Driver
+func NewS3Driver(policy *Policy) (*S3Driver, error) {
+	cfg := aws.NewConfig().
+		WithRegion(policy.Region).
+		WithCredentials(credentials.NewStaticCredentials(policy.AccessKey, policy.SecretKey, "")).
+		WithDisableSSL(!policy.UseHTTPS)
+
+	if policy.Endpoint != "" {
+		cfg = cfg.WithEndpoint(policy.Endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Driver{bucket: policy.Bucket, api: s3.New(sess)}, nil
+}
+
+func (d *S3Driver) Put(ctx context.Context, key string, reader io.Reader, size int64) error {
+	readSeeker, ok := reader.(io.ReadSeeker)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	_, err := d.api.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(d.bucket),
+		Key:           aws.String(key),
+		Body:          readSeeker,
+		ContentLength: aws.Int64(size),
+	})
+	return err
+}
+
+func (d *S3Driver) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := d.api.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+func (d *S3Driver) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	out, err := d.api.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ObjectInfo{Key: key}
+	if out.ContentLength != nil {
+		info.Size = *out.ContentLength
+	}
+	if out.ContentType != nil {
+		info.MimeType = *out.ContentType
+	}
+	if out.LastModified != nil {
+		info.LastModified = *out.LastModified
+	}
+	return info, nil
+}
+
+func (d *S3Driver) Delete(ctx context.Context, key string) error {
+	_, err := d.api.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (d *S3Driver) List(ctx context.Context, prefix, marker string, limit int) ([]ObjectInfo, string, error) {
+	out, err := d.api.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:            aws.String(d.bucket),
+		Prefix:            aws.String(prefix),
+		ContinuationToken: nonEmptyStrPtr(marker),
+		MaxKeys:           aws.Int64(int64(limit)),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	infos := make([]ObjectInfo, 0, len(out.Contents))
+	for _, obj := range out.Contents {
+		info := ObjectInfo{Key: aws.StringValue(obj.Key), Size: aws.Int64Value(obj.Size)}
+		if obj.LastModified != nil {
+			info.LastModified = *obj.LastModified
+		}
+		infos = append(infos, info)
+	}
+
+	nextMarker := ""
+	if out.NextContinuationToken != nil {
+		nextMarker = *out.NextContinuationToken
+	}
+
+	return infos, nextMarker, nil
+}
+
+func (d *S3Driver) Sign(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	req, _ := d.api.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(d.bucket),
+		Key:    aws.String(key),
+	})
+	return req.Presign(ttl)
+}
+
+func (d *S3Driver) Fop(ctx context.Context, key, fops string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func nonEmptyStrPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return aws.String(s)
+}
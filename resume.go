@@ -0,0 +1,184 @@
+package kodo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/qiniu/api.v7/storage"
+)
+
+// Recorder 用于持久化分片上传进度，上传中断后可以根据记录的状态从最后一个
+// 提交成功的分片继续上传，而不用重新上传整个文件
+// 实现方可以把状态存到本地磁盘、Redis 等任意介质
+type Recorder interface {
+	Get(recorderKey string) ([]byte, error)
+	Set(recorderKey string, data []byte) error
+}
+
+// ProgressFunc 分片上传进度回调，uploaded 为已上传字节数，total 为文件总大小
+type ProgressFunc func(uploaded, total int64)
+
+// ResumeOption 用于配置分片上传的行为，字段均为可选，零值时使用默认值
+type ResumeOption struct {
+	Recorder    Recorder     // 分片状态持久化，为 nil 时不记录，上传中断后无法续传
+	RecorderKey string       // 记录续传状态使用的 key，默认使用上传的 path
+	Progress    ProgressFunc // 上传进度回调
+	ChunkSize   int          // 分片大小，默认 4MB
+	TryTimes    int          // 单个分片上传失败时的重试次数，默认 3
+}
+
+const (
+	defaultChunkSize = 4 * 1024 * 1024
+	defaultTryTimes  = 3
+)
+
+// ResumeWriter 使用七牛分片上传把 reader 中的数据写入对象存储，相比 Writer 使用的
+// FormUploader 一次性上传，ResumeWriter 把文件切分成多个分片并发上传并支持断点续传，
+// 适合上传几百 MB 以上的大文件 args 是可选参数，含义同 Writer:
+// pipName  = args[0] string 七牛多媒体处理队列名字
+// fops = args[1] string 文件操作指令
+// extraParams   = args[2] map[string]string 用户自定义的扩展信息
+// retBody       = args[3] string 用户自定义回调结构体
+// cbURL         = args[4] string 客户自定义的回调地址
+func (client *Client) ResumeWriter(path string, reader io.Reader, fSize int64, overWriter bool, opt *ResumeOption, args ...interface{}) (*State, error) {
+	var (
+		pipName     string
+		fops        string
+		retBody     = defaultRetBody
+		extraParams = make(map[string]string)
+		cbURL       string
+		cbBody      string
+		scope       = client.bucket
+	)
+
+	if overWriter {
+		scope = fmt.Sprintf("%s:%s", client.bucket, path)
+	}
+
+	switch len(args) {
+	case 1:
+		return nil, ErrMissFops
+	case 5:
+		cbURL = args[4].(string)
+		fallthrough
+	case 4:
+		retBody = args[3].(string)
+		cbBody = retBody
+		fallthrough
+	case 3:
+		extraParams = args[2].(map[string]string)
+		fallthrough
+	case 2:
+		pipName = args[0].(string)
+		fops = args[1].(string)
+	}
+
+	extraParams["x:name"] = path
+
+	policy := &storage.PutPolicy{
+		Scope:              scope,
+		CallbackURL:        cbURL,
+		ReturnBody:         retBody,
+		PersistentOps:      fops,
+		CallbackBody:       cbBody,
+		PersistentPipeline: pipName,
+	}
+
+	if opt == nil {
+		opt = &ResumeOption{}
+	}
+
+	chunkSize := opt.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	tryTimes := opt.TryTimes
+	if tryTimes <= 0 {
+		tryTimes = defaultTryTimes
+	}
+
+	recorderKey := opt.RecorderKey
+	if recorderKey == "" {
+		recorderKey = path
+	}
+
+	extra := &storage.RputExtra{
+		Params:    extraParams,
+		ChunkSize: chunkSize,
+		TryTimes:  tryTimes,
+	}
+
+	// 已完成分片的进度状态，按分片序号存放，重启后从 Recorder 中恢复，恢复后会
+	// 被传给 RputExtra.Progresses 让 SDK 跳过已经上传成功的分片
+	// SDK 内部用一个 worker pool 并发上传分片，Notify 会被多个 worker 并发回调，
+	// 所以这里用 mu 保护 progresses 的读写
+	var (
+		mu         sync.Mutex
+		progresses []storage.BlkputRet
+	)
+	if opt.Recorder != nil {
+		if data, err := opt.Recorder.Get(recorderKey); err == nil && len(data) > 0 {
+			json.Unmarshal(data, &progresses)
+		}
+		extra.Progresses = progresses
+	}
+
+	// 分片可能乱序完成，已上传字节数按每个分片的 Offset 累加，同样由 mu 保护
+	var uploaded int64
+	blkDone := make(map[int]int64)
+	for i, p := range progresses {
+		blkDone[i] = int64(p.Offset)
+		uploaded += int64(p.Offset)
+	}
+
+	extra.Notify = func(blkIdx int, blkSize int, ret *storage.BlkputRet) {
+		mu.Lock()
+		uploaded += int64(ret.Offset) - blkDone[blkIdx]
+		blkDone[blkIdx] = int64(ret.Offset)
+		current := uploaded
+		var data []byte
+		var err error
+		if opt.Recorder != nil {
+			for len(progresses) <= blkIdx {
+				progresses = append(progresses, storage.BlkputRet{})
+			}
+			progresses[blkIdx] = *ret
+			data, err = json.Marshal(progresses)
+		}
+		mu.Unlock()
+		if opt.Recorder != nil && err == nil {
+			opt.Recorder.Set(recorderKey, data)
+		}
+		if opt.Progress != nil {
+			opt.Progress(current, fSize)
+		}
+	}
+
+	if client.autoZone {
+		client.Region(context.Background())
+	}
+
+	resumeUploader := storage.NewResumeUploader(client.res().config)
+
+	ret := State{}
+	err := resumeUploader.Put(
+		context.Background(),
+		&ret,
+		policy.UploadToken(client.mac),
+		path,
+		reader,
+		fSize,
+		extra,
+	)
+	return &ret, err
+}
+
+// ResumePush args 参数请看 ResumeWriter 参数说明
+func (client *Client) ResumePush(path string, data []byte, overWriter bool, opt *ResumeOption, args ...interface{}) (*State, error) {
+	return client.ResumeWriter(path, bytes.NewReader(data), int64(len(data)), overWriter, opt, args...)
+}
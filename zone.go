@@ -0,0 +1,69 @@
+package kodo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/qiniu/api.v7/storage"
+)
+
+// defaultRegionTTL 是 AutoZone 模式下地域信息缓存的默认有效期
+const defaultRegionTTL = time.Hour
+
+// regionCache 缓存一次 storage.GetZone 查询的结果
+type regionCache struct {
+	mu        sync.Mutex
+	zone      *storage.Zone
+	expiresAt time.Time
+}
+
+// Region 返回当前生效的 zone 配置
+// 只有 NewClient 没有显式传入 zoneConf（即 AutoZone 模式）时才会查询，这种情况下
+// 首次调用会触发一次 storage.GetZone(accessKey, bucket) 查询并缓存结果，
+// 缓存过期前的后续调用直接复用缓存，过期后自动重新查询
+// 私有化部署显式指定了 zoneConf 时返回 nil, nil
+func (client *Client) Region(ctx context.Context) (*storage.Zone, error) {
+	if !client.autoZone {
+		return nil, nil
+	}
+
+	client.regionCache.mu.Lock()
+	defer client.regionCache.mu.Unlock()
+
+	if client.regionCache.zone != nil && time.Now().Before(client.regionCache.expiresAt) {
+		return client.regionCache.zone, nil
+	}
+
+	zone, err := storage.GetZone(client.accessKey, client.bucket)
+	if err != nil {
+		return client.regionCache.zone, err
+	}
+
+	client.regionCache.zone = zone
+	client.regionCache.expiresAt = time.Now().Add(defaultRegionTTL)
+	client.swapZone(zone)
+
+	return zone, nil
+}
+
+// swapZone 用新的 zone 构造一份独立的 *storage.Config 和对应的 bktManager/
+// fopManager/uploader，然后整体原子替换掉旧的一份，而不是就地修改正在被其他
+// 请求并发读取的 *storage.Config.Zone 字段
+func (client *Client) swapZone(zone *storage.Zone) {
+	old := client.res()
+
+	cfg := *old.config
+	cfg.Zone = zone
+
+	client.resources.Store(newClientResources(client.mac, &cfg))
+}
+
+// RefreshRegion 强制重新查询 zone 配置并刷新缓存，用于上传 host 失败后主动切换
+func (client *Client) RefreshRegion(ctx context.Context) (*storage.Zone, error) {
+	client.regionCache.mu.Lock()
+	client.regionCache.zone = nil
+	client.regionCache.mu.Unlock()
+
+	return client.Region(ctx)
+}